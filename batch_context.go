@@ -0,0 +1,194 @@
+package govaluate
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+/*
+EvaluationResult carries the outcome of evaluating an expression against a single parameter
+set as part of a batch: exactly one of Result and Error is meaningful.
+*/
+type EvaluationResult struct {
+	Result any
+	Error  error
+}
+
+/*
+ExpressionFunctionCtx is the context-aware counterpart of ExpressionFunction: a function that
+takes a context.Context as its first argument, ahead of the expression's own arguments.
+
+Nothing in this package calls an ExpressionFunctionCtx, and EvaluateBatchContext does not pass
+its per-item context to any function. There is no way to register one against an
+EvaluableExpression: functions are resolved against the Functions map once, at parse time, and
+baked directly into the compiled evaluationStage tree, which never consults the map again.
+*/
+type ExpressionFunctionCtx func(ctx context.Context, arguments ...any) (any, error)
+
+/*
+BatchOption configures EvaluateBatchContext. Options are applied in order, so later options
+override earlier ones.
+*/
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	failFast       bool
+	perItemTimeout time.Duration
+}
+
+/*
+FailFast causes EvaluateBatchContext to cancel the remaining work and return as soon as any
+parameter set fails to evaluate, rather than collecting a result for every parameter set.
+*/
+func FailFast() BatchOption {
+	return func(o *batchOptions) {
+		o.failFast = true
+	}
+}
+
+/*
+PerItemTimeout wraps the evaluation of each parameter set in a context derived from the one
+passed to EvaluateBatchContext, bounding how long a single evaluation may run regardless of the
+overall context's deadline.
+*/
+func PerItemTimeout(d time.Duration) BatchOption {
+	return func(o *batchOptions) {
+		o.perItemTimeout = d
+	}
+}
+
+/*
+EvaluateBatchContext evaluates the expression once per entry in params, using up to workers
+concurrent goroutines (0 or negative means one goroutine per parameter set). Unlike
+EvaluateBatchParallel, it accepts a context.Context: cancelling ctx stops dispatch of further
+parameter sets, and in-flight evaluations are interrupted at the next evaluationStage boundary -
+this is done by driving each evaluation through EvaluateDebug with a Debugger that checks
+ctx.Err() before and after every stage, rather than waiting for the whole expression to finish.
+With FailFast, the first evaluation error cancels the group and EvaluateBatchContext returns
+that error immediately instead of a full []EvaluationResult; without it, every per-item error -
+including cancellation - is reported through that item's EvaluationResult.Error, never as a
+top-level error.
+*/
+func (this EvaluableExpression) EvaluateBatchContext(ctx context.Context, params []map[string]interface{}, workers int, opts ...BatchOption) ([]EvaluationResult, error) {
+
+	options := &batchOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	results := make([]EvaluationResult, len(params))
+
+	if len(params) == 0 {
+		return results, nil
+	}
+
+	if workers <= 0 {
+		workers = len(params)
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, workers)
+
+	for i, p := range params {
+		i, p := i, p
+
+		group.Go(func() error {
+			recordErr := func(err error) error {
+				if options.failFast {
+					return err
+				}
+				results[i] = EvaluationResult{Error: err}
+				return nil
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-groupCtx.Done():
+				return recordErr(groupCtx.Err())
+			}
+
+			itemCtx := groupCtx
+			if options.perItemTimeout > 0 {
+				var cancel context.CancelFunc
+				itemCtx, cancel = context.WithTimeout(groupCtx, options.perItemTimeout)
+				defer cancel()
+			}
+
+			if err := itemCtx.Err(); err != nil {
+				return recordErr(err)
+			}
+
+			value, err := this.evaluateWithContext(itemCtx, p)
+			if err != nil {
+				return recordErr(err)
+			}
+
+			results[i] = EvaluationResult{Result: value}
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ctxDebugger aborts evaluation as soon as ctx is done, giving EvaluateBatchContext a stage
+// boundary to check cancellation at instead of only before and after the whole expression.
+type ctxDebugger struct {
+	ctx context.Context
+}
+
+func (d ctxDebugger) Before(stage *evaluationStage) Action {
+	if d.ctx.Err() != nil {
+		return Abort
+	}
+	return Continue
+}
+
+func (d ctxDebugger) After(stage *evaluationStage, value any) Action {
+	if d.ctx.Err() != nil {
+		return Abort
+	}
+	return Continue
+}
+
+/*
+evaluateWithContext evaluates a single parameter set under ctx, aborting at the next
+evaluationStage boundary if ctx is done rather than running the expression to completion.
+*/
+func (this EvaluableExpression) evaluateWithContext(ctx context.Context, parameters map[string]interface{}) (any, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	value, err := this.EvaluateDebug(MapParameters(parameters), ctxDebugger{ctx: ctx})
+	if err == ErrDebugAborted {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+	}
+	return value, err
+}
+
+/*
+EvaluateBatchParallel evaluates the expression once per entry in paramSets, using up to workers
+concurrent goroutines (0 meaning fully parallel). It is now a thin wrapper around
+EvaluateBatchContext with a background context and no options, kept for callers that don't need
+cancellation.
+*/
+func (this EvaluableExpression) EvaluateBatchParallel(paramSets []map[string]interface{}, workers int) []EvaluationResult {
+
+	results, err := this.EvaluateBatchContext(context.Background(), paramSets, workers)
+	if err != nil {
+		// EvaluateBatchContext without FailFast never returns a top-level error.
+		panic(err)
+	}
+	return results
+}
@@ -0,0 +1,168 @@
+package govaluate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestEvaluateBatchContext verifies successful evaluation of a batch under a live context.
+func TestEvaluateBatchContext(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	paramSets := []map[string]interface{}{
+		{"foo": 1.0, "bar": 2.0},
+		{"foo": 5.0, "bar": 10.0},
+		{"foo": 100.0, "bar": 200.0},
+	}
+	expected := []float64{3.0, 15.0, 300.0}
+
+	results, err := expression.EvaluateBatchContext(context.Background(), paramSets, 2)
+	if err != nil {
+		t.Fatalf("Unexpected top-level error: %v", err)
+	}
+
+	if len(results) != len(paramSets) {
+		t.Fatalf("Expected %d results, got %d", len(paramSets), len(results))
+	}
+
+	for i, result := range results {
+		if result.Error != nil {
+			t.Errorf("Result %d: unexpected error: %v", i, result.Error)
+			continue
+		}
+		if result.Result != expected[i] {
+			t.Errorf("Result %d: expected %v, got %v", i, expected[i], result.Result)
+		}
+	}
+}
+
+// TestEvaluateBatchContextCancelled verifies that a pre-cancelled context stops the batch and
+// surfaces a per-item error for every parameter set rather than a top-level error.
+func TestEvaluateBatchContextCancelled(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	paramSets := []map[string]interface{}{
+		{"foo": 1.0, "bar": 2.0},
+		{"foo": 5.0, "bar": 10.0},
+	}
+
+	results, err := expression.EvaluateBatchContext(ctx, paramSets, 2)
+	if err != nil {
+		t.Fatalf("Unexpected top-level error: %v", err)
+	}
+
+	for i, result := range results {
+		if result.Error == nil {
+			t.Errorf("Result %d: expected a cancellation error, got nil", i)
+		}
+	}
+}
+
+// TestEvaluateBatchContextFailFast verifies that FailFast returns the first error immediately
+// as a top-level error rather than a full result slice.
+func TestEvaluateBatchContextFailFast(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	paramSets := []map[string]interface{}{
+		{"foo": 1.0, "bar": 2.0},
+		{"foo": 5.0}, // missing 'bar', should cause error
+		{"foo": 100.0, "bar": 200.0},
+	}
+
+	results, err := expression.EvaluateBatchContext(context.Background(), paramSets, 1, FailFast())
+	if err == nil {
+		t.Fatal("Expected a top-level error with FailFast, got nil")
+	}
+	if results != nil {
+		t.Errorf("Expected nil results with FailFast, got %v", results)
+	}
+}
+
+// TestEvaluateBatchContextPerItemTimeout verifies that a generous PerItemTimeout leaves a
+// successful evaluation and its result unaffected.
+func TestEvaluateBatchContextPerItemTimeout(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	paramSets := []map[string]interface{}{
+		{"foo": 1.0, "bar": 2.0},
+	}
+
+	results, err := expression.EvaluateBatchContext(context.Background(), paramSets, 1, PerItemTimeout(time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected top-level error: %v", err)
+	}
+	if results[0].Error != nil {
+		t.Errorf("Expected success with a generous timeout, got error: %v", results[0].Error)
+	}
+	if results[0].Result != 3.0 {
+		t.Errorf("Expected 3.0, got %v", results[0].Result)
+	}
+}
+
+// TestEvaluateBatchContextShortCircuits verifies that EvaluateBatchContext, which evaluates
+// through the same debug-stage walk as EvaluateDebug, still short-circuits || like Evaluate:
+// a missing right-hand parameter must never be reached once the left side is already true.
+func TestEvaluateBatchContextShortCircuits(t *testing.T) {
+	expression, err := NewEvaluableExpression("alwaysTrue || missing")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	paramSets := []map[string]interface{}{
+		{"alwaysTrue": true},
+	}
+
+	results, err := expression.EvaluateBatchContext(context.Background(), paramSets, 1)
+	if err != nil {
+		t.Fatalf("Unexpected top-level error: %v", err)
+	}
+	if results[0].Error != nil {
+		t.Fatalf("Expected the missing right-hand side to be short-circuited away, got error: %v", results[0].Error)
+	}
+	if results[0].Result != true {
+		t.Errorf("Expected true, got %v", results[0].Result)
+	}
+}
+
+// TestEvaluateBatchParallelOnContextPrimitive re-verifies the previously existing
+// EvaluateBatchParallel behaviour now that it is implemented on top of EvaluateBatchContext.
+func TestEvaluateBatchParallelOnContextPrimitive(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo * bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	paramSets := []map[string]interface{}{
+		{"foo": 2.0, "bar": 3.0},
+		{"foo": 4.0, "bar": 5.0},
+	}
+	expected := []float64{6.0, 20.0}
+
+	results := expression.EvaluateBatchParallel(paramSets, 0)
+
+	for i, result := range results {
+		if result.Error != nil {
+			t.Errorf("Result %d: unexpected error: %v", i, result.Error)
+			continue
+		}
+		if result.Result != expected[i] {
+			t.Errorf("Result %d: expected %v, got %v", i, expected[i], result.Result)
+		}
+	}
+}
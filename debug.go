@@ -0,0 +1,260 @@
+package govaluate
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+Action tells EvaluateDebug how to proceed after a Debugger callback runs.
+*/
+type Action int
+
+const (
+	// Continue evaluates the stage (or its result) normally.
+	Continue Action = iota
+	// Skip bypasses evaluation of the current stage, yielding a nil value for it.
+	Skip
+	// Abort stops evaluation immediately, returning ErrDebugAborted.
+	Abort
+)
+
+/*
+Debugger lets a caller observe, and optionally control, evaluation of an EvaluableExpression
+one evaluationStage at a time. Before is invoked just before a stage is evaluated, After once
+it has produced a value. Implementations can use this to build breakpoints, watch-expressions,
+or step-in/step-over behavior in a CLI or GUI.
+*/
+type Debugger interface {
+	Before(stage *evaluationStage) Action
+	After(stage *evaluationStage, value any) Action
+}
+
+// ErrDebugAborted is returned by EvaluateDebug when a Debugger returns Abort.
+var ErrDebugAborted = errors.New("evaluation aborted by debugger")
+
+/*
+TraceStep records a single evaluationStage visited while producing a Trace: its nesting depth,
+operator symbol, a best-effort rendering of the sub-expression it corresponds to (see
+stageSource), the left/right values it was handed, and the value (or error) it returned. A stage
+skipped by short-circuiting never gets a TraceStep - there is nothing to report for a branch
+Evaluate itself would never have touched either.
+*/
+type TraceStep struct {
+	Depth  int
+	Symbol OperatorSymbol
+	Source string
+	Left   any
+	Right  any
+	Result any
+	Err    error
+}
+
+/*
+Trace is the ordered set of TraceSteps recorded by EvaluateTrace, in the order each stage
+finished resolving (left subtree, then right subtree, then the stage itself).
+*/
+type Trace struct {
+	Steps []TraceStep
+}
+
+/*
+debugContext carries per-call tracing/debugging state through a single evaluation. Stages are
+shared across goroutines evaluating the same EvaluableExpression concurrently, so this state
+must never be attached to the stage tree itself - see TestTraceDoesNotBreakConcurrentEvaluation.
+*/
+type debugContext struct {
+	depth    int
+	trace    *Trace
+	debugger Debugger
+}
+
+/*
+EvaluateTrace evaluates the expression exactly like Evaluate, but additionally returns a Trace
+describing every evaluationStage visited along the way.
+*/
+func (this EvaluableExpression) EvaluateTrace(parameters Parameters) (*Trace, any, error) {
+
+	ctx := &debugContext{trace: &Trace{}}
+
+	value, err := this.evaluateStageDebug(ctx, this.evaluationStages, parameters)
+	return ctx.trace, value, err
+}
+
+/*
+EvaluateDebug evaluates the expression while invoking the given Debugger's Before for every
+evaluationStage it visits and After for every stage that produces a value, allowing the caller to
+implement breakpoints or step execution. After is not called for a skipped stage or one that
+returns an error.
+*/
+func (this EvaluableExpression) EvaluateDebug(parameters Parameters, debugger Debugger) (any, error) {
+
+	ctx := &debugContext{debugger: debugger}
+
+	return this.evaluateStageDebug(ctx, this.evaluationStages, parameters)
+}
+
+/*
+evaluateStageDebug mirrors evaluateStage's control flow rather than walking the stage tree
+unconditionally: it honours stage.isShortCircuitable() so a taken && / || / ?? / ternary branch
+never evaluates (or traces) the side Evaluate itself would have skipped, and it runs the same
+operand type checks Evaluate relies on before calling stage.operator, so a type-mismatched
+expression returns the same typed error Evaluate would instead of panicking inside the operator.
+*/
+func (this EvaluableExpression) evaluateStageDebug(ctx *debugContext, stage *evaluationStage, parameters Parameters) (any, error) {
+
+	if stage == nil {
+		return nil, nil
+	}
+
+	if ctx.debugger != nil {
+		switch ctx.debugger.Before(stage) {
+		case Abort:
+			return nil, ErrDebugAborted
+		case Skip:
+			return nil, nil
+		}
+	}
+
+	ctx.depth++
+
+	var left, right any
+	var leftSource, rightSource string
+	var err error
+
+	if stage.leftStage != nil {
+		left, err = this.evaluateStageDebug(ctx, stage.leftStage, parameters)
+		if err != nil {
+			ctx.depth--
+			return nil, err
+		}
+		leftSource = this.lastTraceSource(ctx)
+	}
+
+	if stage.isShortCircuitable() {
+		switch stage.symbol {
+		case AND:
+			if left == false {
+				return this.finishDebugStage(ctx, stage, left, right, leftSource, rightSource, false, nil)
+			}
+		case OR:
+			if left == true {
+				return this.finishDebugStage(ctx, stage, left, right, leftSource, rightSource, true, nil)
+			}
+		case COALESCE:
+			if left != nil {
+				return this.finishDebugStage(ctx, stage, left, right, leftSource, rightSource, left, nil)
+			}
+		case TERNARY_TRUE:
+			if left == false {
+				return this.finishDebugStage(ctx, stage, left, right, leftSource, rightSource, nil, nil)
+			}
+		case TERNARY_FALSE:
+			// left is the taken '?' branch's value, or nil when its condition was false.
+			if left != nil {
+				return this.finishDebugStage(ctx, stage, left, right, leftSource, rightSource, left, nil)
+			}
+		}
+	}
+
+	if stage.rightStage != nil {
+		right, err = this.evaluateStageDebug(ctx, stage.rightStage, parameters)
+		if err != nil {
+			ctx.depth--
+			return nil, err
+		}
+		rightSource = this.lastTraceSource(ctx)
+	}
+
+	if typeErr := checkStageOperandTypes(stage, left, right); typeErr != nil {
+		return this.finishDebugStage(ctx, stage, left, right, leftSource, rightSource, nil, typeErr)
+	}
+
+	value, err := stage.operator(left, right, parameters)
+	return this.finishDebugStage(ctx, stage, left, right, leftSource, rightSource, value, err)
+}
+
+// checkStageOperandTypes runs the same operand type checks evaluateStage runs before calling
+// stage.operator, so a mismatch surfaces as the typed error Evaluate returns rather than a
+// panic from inside the operator (e.g. a failed left.(float64) assertion).
+func checkStageOperandTypes(stage *evaluationStage, left, right any) error {
+	if stage.typeCheck != nil {
+		if !stage.typeCheck(left, right) {
+			return errors.New(fmt.Sprintf(stage.typeErrorFormat, left, right))
+		}
+		return nil
+	}
+
+	if stage.leftTypeCheck != nil && !stage.leftTypeCheck(left) {
+		return errors.New(fmt.Sprintf(stage.typeErrorFormat, left))
+	}
+	if stage.rightTypeCheck != nil && !stage.rightTypeCheck(right) {
+		return errors.New(fmt.Sprintf(stage.typeErrorFormat, right))
+	}
+
+	return nil
+}
+
+// finishDebugStage records this stage's TraceStep (if tracing), runs the After callback (if
+// debugging and err is nil), and returns (value, err) or (nil, ErrDebugAborted) accordingly.
+// Every stage that reaches a short-circuit, a type check or its operator exits through here and
+// gets exactly one TraceStep. A stage skipped or aborted in Before, or whose child failed, returns
+// earlier and gets no TraceStep; After is not called for a stage that returns an error.
+func (this EvaluableExpression) finishDebugStage(ctx *debugContext, stage *evaluationStage, left, right any, leftSource, rightSource string, value any, err error) (any, error) {
+	ctx.depth--
+
+	if ctx.trace != nil {
+		ctx.trace.Steps = append(ctx.trace.Steps, TraceStep{
+			Depth:  ctx.depth,
+			Symbol: stage.symbol,
+			Source: stageSource(stage, leftSource, rightSource, value),
+			Left:   left,
+			Right:  right,
+			Result: value,
+			Err:    err,
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.debugger != nil && ctx.debugger.After(stage, value) == Abort {
+		return nil, ErrDebugAborted
+	}
+
+	return value, nil
+}
+
+// lastTraceSource returns the Source recorded for the stage that most recently finished, which -
+// because finishDebugStage is called exactly once per stage, in post-order - is always the child
+// whose evaluation the caller just returned from. Returns "" when not tracing.
+func (this EvaluableExpression) lastTraceSource(ctx *debugContext) string {
+	if ctx.trace == nil || len(ctx.trace.Steps) == 0 {
+		return ""
+	}
+	return ctx.trace.Steps[len(ctx.trace.Steps)-1].Source
+}
+
+/*
+stageSource names the sub-expression a stage corresponds to. evaluationStage carries no record
+of the token range it was built from, and reconstructing one would mean touching the parser,
+which is out of scope here - so this is deliberately not exact source text. What it gives
+instead: a stage with children renders as its already-reconstructed children joined by its
+operator (e.g. "(1 + (2 * 3))"), which is a genuine structural reconstruction, not just the
+operator name. A literal leaf (symbol == LITERAL) renders as the value it resolved to, which for
+a literal *is* its source text. A parameter leaf carries no name on the stage at all, so it falls
+back to its resolved value labelled as such, rather than silently passing a value off as source.
+*/
+func stageSource(stage *evaluationStage, leftSource, rightSource string, value any) string {
+	switch {
+	case leftSource != "" && rightSource != "":
+		return fmt.Sprintf("(%s %v %s)", leftSource, stage.symbol, rightSource)
+	case leftSource != "":
+		return fmt.Sprintf("%v(%s)", stage.symbol, leftSource)
+	case stage.symbol == LITERAL:
+		return fmt.Sprintf("%v", value)
+	default:
+		return fmt.Sprintf("<%v>", value)
+	}
+}
@@ -0,0 +1,262 @@
+package govaluate
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestEvaluateTrace verifies that EvaluateTrace records TraceSteps ending with the stage that
+// produced the final result, and still produces the same result as Evaluate.
+func TestEvaluateTrace(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar * baz")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	params := map[string]interface{}{"foo": 1.0, "bar": 2.0, "baz": 3.0}
+
+	trace, value, err := expression.EvaluateTrace(MapParameters(params))
+	if err != nil {
+		t.Fatalf("EvaluateTrace returned error: %v", err)
+	}
+
+	if value != 7.0 {
+		t.Errorf("Expected 7.0, got %v", value)
+	}
+
+	if len(trace.Steps) == 0 {
+		t.Fatal("Expected at least one traced step")
+	}
+
+	last := trace.Steps[len(trace.Steps)-1]
+	if last.Result != 7.0 {
+		t.Errorf("Expected final traced step to carry the result 7.0, got %v", last.Result)
+	}
+}
+
+// stepCountingDebugger counts how many stages it was invoked for, without altering evaluation.
+type stepCountingDebugger struct {
+	mu     sync.Mutex
+	before int
+	after  int
+}
+
+func (d *stepCountingDebugger) Before(stage *evaluationStage) Action {
+	d.mu.Lock()
+	d.before++
+	d.mu.Unlock()
+	return Continue
+}
+
+func (d *stepCountingDebugger) After(stage *evaluationStage, value any) Action {
+	d.mu.Lock()
+	d.after++
+	d.mu.Unlock()
+	return Continue
+}
+
+// TestEvaluateDebugContinue verifies that a Debugger which always returns Continue doesn't
+// change the evaluated result.
+func TestEvaluateDebugContinue(t *testing.T) {
+	expression, err := NewEvaluableExpression("(foo > 10 && bar < 100) || baz == 'test'")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	params := map[string]interface{}{"foo": 5.0, "bar": 5.0, "baz": "test"}
+
+	debugger := &stepCountingDebugger{}
+	value, err := expression.EvaluateDebug(MapParameters(params), debugger)
+	if err != nil {
+		t.Fatalf("EvaluateDebug returned error: %v", err)
+	}
+
+	if value != true {
+		t.Errorf("Expected true, got %v", value)
+	}
+
+	if debugger.before == 0 || debugger.before != debugger.after {
+		t.Errorf("Expected matching non-zero Before/After counts, got before=%d after=%d", debugger.before, debugger.after)
+	}
+}
+
+// abortingDebugger aborts as soon as it has seen the given number of stages.
+type abortingDebugger struct {
+	mu      sync.Mutex
+	seen    int
+	abortAt int
+}
+
+func (d *abortingDebugger) Before(stage *evaluationStage) Action {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen++
+	if d.seen >= d.abortAt {
+		return Abort
+	}
+	return Continue
+}
+
+func (d *abortingDebugger) After(stage *evaluationStage, value any) Action {
+	return Continue
+}
+
+// TestEvaluateDebugAbort verifies that a Debugger returning Abort stops evaluation early with
+// ErrDebugAborted.
+func TestEvaluateDebugAbort(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar * baz")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	params := map[string]interface{}{"foo": 1.0, "bar": 2.0, "baz": 3.0}
+
+	_, err = expression.EvaluateDebug(MapParameters(params), &abortingDebugger{abortAt: 1})
+	if err != ErrDebugAborted {
+		t.Errorf("Expected ErrDebugAborted, got %v", err)
+	}
+}
+
+// TestEvaluateDebugShortCircuitsOr verifies that EvaluateDebug, like Evaluate, never evaluates
+// (or visits) the right-hand side of a true || ... expression: a missing parameter on the right
+// would error if it were touched, so a nil error together with a value of true shows it wasn't.
+func TestEvaluateDebugShortCircuitsOr(t *testing.T) {
+	expression, err := NewEvaluableExpression("alwaysTrue || missing")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	params := map[string]interface{}{"alwaysTrue": true}
+
+	debugger := &stepCountingDebugger{}
+	value, err := expression.EvaluateDebug(MapParameters(params), debugger)
+	if err != nil {
+		t.Fatalf("EvaluateDebug returned error: %v", err)
+	}
+	if value != true {
+		t.Errorf("Expected true, got %v", value)
+	}
+	if debugger.before != 2 {
+		t.Errorf("Expected only the OR stage and its left operand to be visited, got %d stages", debugger.before)
+	}
+}
+
+// TestEvaluateDebugShortCircuitsAnd verifies that EvaluateDebug never evaluates the right-hand
+// side of a false && ... expression, matching Evaluate's short-circuit behavior.
+func TestEvaluateDebugShortCircuitsAnd(t *testing.T) {
+	expression, err := NewEvaluableExpression("alwaysFalse && missing")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	params := map[string]interface{}{"alwaysFalse": false}
+
+	debugger := &stepCountingDebugger{}
+	value, err := expression.EvaluateDebug(MapParameters(params), debugger)
+	if err != nil {
+		t.Fatalf("EvaluateDebug returned error: %v", err)
+	}
+	if value != false {
+		t.Errorf("Expected false, got %v", value)
+	}
+	if debugger.before != 2 {
+		t.Errorf("Expected only the AND stage and its left operand to be visited, got %d stages", debugger.before)
+	}
+}
+
+// TestEvaluateDebugTypeMismatchReturnsError verifies that a type-mismatched expression returns
+// the same typed error Evaluate would, rather than panicking inside the operator.
+func TestEvaluateDebugTypeMismatchReturnsError(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo > bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	params := map[string]interface{}{"foo": "abc", "bar": 1.0}
+
+	_, err = expression.EvaluateDebug(MapParameters(params), &stepCountingDebugger{})
+	if err == nil {
+		t.Fatal("Expected a type-mismatch error, got nil")
+	}
+}
+
+// TestEvaluateTraceReconstructsSource verifies that traced Source is more than an echo of the
+// operator symbol: the root composite stage's Source is not just its bare operator.
+func TestEvaluateTraceReconstructsSource(t *testing.T) {
+	expression, err := NewEvaluableExpression("1 + 2")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	trace, _, err := expression.EvaluateTrace(MapParameters(map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("EvaluateTrace returned error: %v", err)
+	}
+
+	last := trace.Steps[len(trace.Steps)-1]
+	if last.Source == fmt.Sprintf("%v", last.Symbol) {
+		t.Errorf("Expected Source to be more than the bare operator symbol, got %q", last.Source)
+	}
+}
+
+// TestTraceDoesNotBreakConcurrentEvaluation mirrors TestConcurrentEvaluation, but interleaves
+// EvaluateTrace calls on the same *EvaluableExpression to show that trace state never mutates
+// the shared evaluationStage tree.
+func TestTraceDoesNotBreakConcurrentEvaluation(t *testing.T) {
+	expression, err := NewEvaluableExpression("(requests_made * requests_succeeded / 100) >= 90")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	const numGoroutines = 100
+	const numIterations = 100
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+
+	errors := make(chan error, numGoroutines*numIterations)
+
+	for i := 0; i < numGoroutines; i++ {
+		go func(id int) {
+			defer wg.Done()
+
+			for j := 0; j < numIterations; j++ {
+				params := map[string]interface{}{
+					"requests_made":      100.0,
+					"requests_succeeded": float64(85 + (id+j)%20),
+				}
+
+				expected := params["requests_succeeded"].(float64) >= 90.0
+
+				if j%2 == 0 {
+					result, err := expression.Evaluate(params)
+					if err != nil {
+						errors <- err
+						return
+					}
+					if result != expected {
+						t.Errorf("Expected %v, got %v for params %v", expected, result, params)
+					}
+					continue
+				}
+
+				_, result, err := expression.EvaluateTrace(MapParameters(params))
+				if err != nil {
+					errors <- err
+					return
+				}
+				if result != expected {
+					t.Errorf("Expected %v, got %v for params %v", expected, result, params)
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errors)
+
+	for err := range errors {
+		t.Errorf("Evaluation error: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package govaluate
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFutureResolveTwiceIsSafe verifies that resolving a Future more than once keeps the first
+// value and does not panic or deadlock waiters.
+func TestFutureResolveTwiceIsSafe(t *testing.T) {
+	future := NewResolvableFuture()
+
+	future.Resolve("first", nil)
+	future.Resolve("second", errors.New("should be ignored"))
+
+	value, err := future.Await(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value != "first" {
+		t.Errorf("Expected 'first', got %v", value)
+	}
+}
+
+// TestFutureApplyRunsOnce verifies that Apply's transform runs exactly once even when the
+// resulting Future is awaited multiple times.
+func TestFutureApplyRunsOnce(t *testing.T) {
+	source := NewResolvableFuture()
+
+	var calls int32
+	chained := source.Apply(func(value any) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return value.(float64) * 2, nil
+	})
+
+	source.Resolve(21.0, nil)
+
+	for i := 0; i < 5; i++ {
+		value, err := chained.Await(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if value != 42.0 {
+			t.Errorf("Expected 42.0, got %v", value)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected Apply's function to run exactly once, ran %d times", got)
+	}
+}
+
+// TestFutureAwaitRespectsContext verifies that Await returns promptly with ctx.Err() when the
+// context is cancelled before the Future resolves.
+func TestFutureAwaitRespectsContext(t *testing.T) {
+	future := NewResolvableFuture()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := future.Await(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestEvaluateAsync verifies that EvaluateAsync's Future resolves with the same result as a
+// synchronous Evaluate call.
+func TestEvaluateAsync(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	future := expression.EvaluateAsync(MapParameters{"foo": 1.0, "bar": 2.0})
+
+	value, err := future.Await(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value != 3.0 {
+		t.Errorf("Expected 3.0, got %v", value)
+	}
+}
+
+// TestEvaluateBatchAsync verifies that each parameter set's Future resolves independently and
+// in the order the caller chooses to await them.
+func TestEvaluateBatchAsync(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	paramSets := []map[string]interface{}{
+		{"foo": 1.0, "bar": 2.0},
+		{"foo": 5.0, "bar": 10.0},
+		{"foo": 100.0, "bar": 200.0},
+	}
+	expected := []float64{3.0, 15.0, 300.0}
+
+	futures := expression.EvaluateBatchAsync(paramSets)
+
+	for i, future := range futures {
+		value, err := future.Await(context.Background())
+		if err != nil {
+			t.Errorf("Future %d: unexpected error: %v", i, err)
+			continue
+		}
+		if value != expected[i] {
+			t.Errorf("Future %d: expected %v, got %v", i, expected[i], value)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package govaluate
+
+import (
+	"testing"
+	"time"
+)
+
+// fuzzSeedExpressions are drawn from the sample expressions already exercised by the
+// concurrent-evaluation tests and batch examples in this package, plus a handful of literals, function calls,
+// accessors, ternaries, regex operators and nested parentheses to widen the corpus.
+var fuzzSeedExpressions = []string{
+	"(requests_made * requests_succeeded / 100) >= 90",
+	"foo + bar * baz",
+	"(foo > 10 && bar < 100) || baz == 'test'",
+	"user_role == 'admin' || resource_owner == user_id",
+	"foo > threshold",
+	"1",
+	"'a string literal'",
+	"-1.5e10",
+	"true ? 1 : 2",
+	"foo ? bar : baz",
+	"max(1, 2, 3)",
+	"len(foo.bar)",
+	"foo.bar.baz",
+	"foo =~ '^[a-z]+$'",
+	"foo !~ 'bad'",
+	"((foo + bar) * (baz - 1)) / 2",
+	"",
+	"(((",
+	"foo &&",
+}
+
+// fuzzParameters is a deterministic Parameters implementation used by FuzzEvaluateExpression so
+// that a given expression always sees the same values, making failures reproducible.
+type fuzzParameters struct{}
+
+func (fuzzParameters) Get(name string) (any, error) {
+	switch len(name) % 3 {
+	case 0:
+		return float64(len(name)), nil
+	case 1:
+		return name, nil
+	default:
+		return len(name)%2 == 0, nil
+	}
+}
+
+// fuzzDeadline picks a per-input timeout bounded by the fuzz test's own deadline (if any), so a
+// single stuck input fails fast instead of eating the whole fuzz run's budget.
+func fuzzDeadline(t *testing.T) time.Duration {
+	timeout := 2 * time.Second
+	if deadline, ok := t.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return timeout
+}
+
+// FuzzParseExpression asserts that NewEvaluableExpression never panics and never hangs on
+// arbitrary input, instead returning a typed error for anything it can't parse.
+func FuzzParseExpression(f *testing.F) {
+	for _, seed := range fuzzSeedExpressions {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, expr string) {
+		timeout := fuzzDeadline(t)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("NewEvaluableExpression panicked on %q: %v", expr, r)
+				}
+			}()
+
+			_, _ = NewEvaluableExpression(expr)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatalf("NewEvaluableExpression did not return within %s for %q (possible infinite lexing loop)", timeout, expr)
+		}
+	})
+}
+
+// FuzzEvaluateExpression asserts that evaluating whatever NewEvaluableExpression manages to
+// parse never panics or hangs, only ever returning a value or a typed error.
+func FuzzEvaluateExpression(f *testing.F) {
+	for _, seed := range fuzzSeedExpressions {
+		f.Add(seed)
+	}
+
+	params := fuzzParameters{}
+
+	f.Fuzz(func(t *testing.T, expr string) {
+		expression, err := NewEvaluableExpression(expr)
+		if err != nil {
+			return
+		}
+
+		timeout := fuzzDeadline(t)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("Eval panicked on %q: %v", expr, r)
+				}
+			}()
+
+			_, _ = expression.Eval(params)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatalf("Eval did not return within %s for %q (possible infinite evaluation loop)", timeout, expr)
+		}
+	})
+}
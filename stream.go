@@ -0,0 +1,188 @@
+package govaluate
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+/*
+StreamOption configures EvaluateStream.
+*/
+type StreamOption func(*streamOptions)
+
+type streamOptions struct {
+	unordered bool
+}
+
+/*
+Unordered lets EvaluateStream emit results as soon as they're computed, instead of preserving
+the order parameter sets arrived on the input channel. This avoids the reorder buffer entirely
+and is useful when consumers don't care which input a result came from.
+*/
+func Unordered() StreamOption {
+	return func(o *streamOptions) {
+		o.unordered = true
+	}
+}
+
+/*
+sequencedResult pairs an EvaluationResult with the order it was read off the input channel, so
+ordered mode can buffer out-of-order completions until it's their turn. release must be called
+once the result has been emitted (or dropped on cancellation) - it frees the dispatch slot the
+item was occupying, which is what bounds the reorder heap to workers entries rather than letting
+one slow item buffer an unbounded number of faster ones behind it.
+*/
+type sequencedResult struct {
+	seq     int
+	result  EvaluationResult
+	release func()
+}
+
+/*
+resultHeap is a min-heap of sequencedResult ordered by seq, used to hold results that finished
+out of order until the next one due can be emitted.
+*/
+type resultHeap []sequencedResult
+
+func (h resultHeap) Len() int           { return len(h) }
+func (h resultHeap) Less(i, j int) bool { return h[i].seq < h[j].seq }
+func (h resultHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *resultHeap) Push(x any)        { *h = append(*h, x.(sequencedResult)) }
+func (h *resultHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+/*
+EvaluateStream reads parameter sets from in, evaluates each through a fixed-size pool of
+workers (built on EvaluateBatchContext, so cancellation behaves the same as in the batch APIs;
+each item is evaluated with no BatchOption, so EvaluateStream applies no per-item timeout), and
+emits results on the returned channel. By default results are emitted in
+the same order their parameter sets arrived, using a reorder buffer bounded to workers entries:
+a dispatch slot isn't freed until its result has actually been emitted, so a single slow item
+can hold up at most workers-1 completed-but-unordered results behind it, never an unbounded
+number - with Unordered, results are emitted as soon as they complete and the buffer is never
+needed. Unlike EvaluateBatchParallel, EvaluateStream never materializes the full input as a
+slice, so it is suitable for unbounded streams such as log lines, metrics, or policy checks.
+Closing in drains any pending work and closes the output channel; cancelling ctx aborts
+promptly, discarding unfinished work.
+*/
+func (this EvaluableExpression) EvaluateStream(ctx context.Context, in <-chan map[string]interface{}, workers int, opts ...StreamOption) <-chan EvaluationResult {
+
+	options := &streamOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if workers <= 0 {
+		workers = 1
+	}
+
+	out := make(chan EvaluationResult)
+	completed := make(chan sequencedResult)
+
+	go func() {
+		defer close(completed)
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, workers)
+
+		seq := 0
+	dispatch:
+		for {
+			select {
+			case <-ctx.Done():
+				break dispatch
+			case params, ok := <-in:
+				if !ok {
+					break dispatch
+				}
+
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					break dispatch
+				}
+
+				wg.Add(1)
+				go func(seq int, params map[string]interface{}) {
+					defer wg.Done()
+
+					var once sync.Once
+					release := func() { once.Do(func() { <-sem }) }
+
+					handedOff := false
+					// If the result never reaches a reader (e.g. ctx is cancelled before the
+					// send below), this goroutine still owns the slot and must free it itself.
+					defer func() {
+						if !handedOff {
+							release()
+						}
+					}()
+
+					results, err := this.EvaluateBatchContext(ctx, []map[string]interface{}{params}, 1)
+
+					var result EvaluationResult
+					if err != nil {
+						result = EvaluationResult{Error: err}
+					} else {
+						result = results[0]
+					}
+
+					select {
+					case completed <- sequencedResult{seq: seq, result: result, release: release}:
+						// The reader now owns freeing this slot, once it has emitted the result.
+						handedOff = true
+					case <-ctx.Done():
+					}
+				}(seq, params)
+
+				seq++
+			}
+		}
+
+		wg.Wait()
+	}()
+
+	go func() {
+		defer close(out)
+
+		if options.unordered {
+			for sr := range completed {
+				select {
+				case out <- sr.result:
+					sr.release()
+				case <-ctx.Done():
+					sr.release()
+					return
+				}
+			}
+			return
+		}
+
+		pending := &resultHeap{}
+		next := 0
+
+		for sr := range completed {
+			heap.Push(pending, sr)
+
+			for pending.Len() > 0 && (*pending)[0].seq == next {
+				item := heap.Pop(pending).(sequencedResult)
+				select {
+				case out <- item.result:
+					item.release()
+				case <-ctx.Done():
+					item.release()
+					return
+				}
+				next++
+			}
+		}
+	}()
+
+	return out
+}
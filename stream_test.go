@@ -0,0 +1,137 @@
+package govaluate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestEvaluateStreamOrdered verifies that results are emitted in the same order their
+// parameter sets were sent, even though evaluation happens concurrently.
+func TestEvaluateStreamOrdered(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + bar")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	in := make(chan map[string]interface{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := expression.EvaluateStream(ctx, in, 4)
+
+	go func() {
+		defer close(in)
+		for i := 0; i < 50; i++ {
+			in <- map[string]interface{}{"foo": float64(i), "bar": 1.0}
+		}
+	}()
+
+	i := 0
+	for result := range out {
+		if result.Error != nil {
+			t.Fatalf("Result %d: unexpected error: %v", i, result.Error)
+		}
+		expected := float64(i) + 1.0
+		if result.Result != expected {
+			t.Errorf("Result %d: expected %v, got %v", i, expected, result.Result)
+		}
+		i++
+	}
+
+	if i != 50 {
+		t.Errorf("Expected 50 results, got %d", i)
+	}
+}
+
+// TestEvaluateStreamUnordered verifies that Unordered mode still delivers every result exactly
+// once, without asserting on their order.
+func TestEvaluateStreamUnordered(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo * 2")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	in := make(chan map[string]interface{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := expression.EvaluateStream(ctx, in, 8, Unordered())
+
+	go func() {
+		defer close(in)
+		for i := 0; i < 50; i++ {
+			in <- map[string]interface{}{"foo": float64(i)}
+		}
+	}()
+
+	seen := make(map[float64]bool)
+	for result := range out {
+		if result.Error != nil {
+			t.Fatalf("Unexpected error: %v", result.Error)
+		}
+		seen[result.Result.(float64)] = true
+	}
+
+	if len(seen) != 50 {
+		t.Errorf("Expected 50 distinct results, got %d", len(seen))
+	}
+}
+
+// TestEvaluateStreamShortCircuits verifies that streamed evaluation, which runs on top of
+// EvaluateBatchContext, still short-circuits || like Evaluate: a missing right-hand parameter
+// must never be reached once the left side is already true.
+func TestEvaluateStreamShortCircuits(t *testing.T) {
+	expression, err := NewEvaluableExpression("alwaysTrue || missing")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	in := make(chan map[string]interface{}, 1)
+	in <- map[string]interface{}{"alwaysTrue": true}
+	close(in)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := expression.EvaluateStream(ctx, in, 1)
+
+	result, ok := <-out
+	if !ok {
+		t.Fatal("Expected one result, channel closed early")
+	}
+	if result.Error != nil {
+		t.Fatalf("Expected the missing right-hand side to be short-circuited away, got error: %v", result.Error)
+	}
+	if result.Result != true {
+		t.Errorf("Expected true, got %v", result.Result)
+	}
+}
+
+// TestEvaluateStreamCancel verifies that cancelling ctx stops the stream and closes the output
+// channel promptly instead of hanging until the input channel is drained.
+func TestEvaluateStreamCancel(t *testing.T) {
+	expression, err := NewEvaluableExpression("foo + 1")
+	if err != nil {
+		t.Fatalf("Failed to create expression: %v", err)
+	}
+
+	in := make(chan map[string]interface{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	out := expression.EvaluateStream(ctx, in, 2)
+
+	in <- map[string]interface{}{"foo": 1.0}
+	<-out
+
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			// draining any results already in flight is fine; just don't hang forever
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("EvaluateStream did not close its output channel promptly after cancellation")
+	}
+}